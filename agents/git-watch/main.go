@@ -2,13 +2,17 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
 	"os/exec"
+	"os/signal"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"syscall"
 	"time"
 )
 
@@ -24,33 +28,69 @@ type MetaData struct {
 	Hash    string `json:"hash"`
 	Author  string `json:"author"`
 	Message string `json:"message"`
+	Stats   *Stats `json:"stats,omitempty"`
+}
+
+// Stats holds the numstat totals for a single commit.
+type Stats struct {
+	Additions    int `json:"additions"`
+	Deletions    int `json:"deletions"`
+	FilesChanged int `json:"files_changed"`
 }
 
 // Config holds the agent configuration
 type Config struct {
-	ServerURL string
-	Since     string
-	DryRun    bool
+	ServerURL    string
+	Since        string
+	DryRun       bool
+	WithStats    bool
+	Watch        bool
+	PollInterval time.Duration
 }
 
+const rescanInterval = 1 * time.Hour
+
 func main() {
 	// Parse configuration
 	config := Config{
-		ServerURL: getEnv("SERVER_URL", "http://localhost:8080"),
-		Since:     getEnv("SINCE", "24 hours ago"),
-		DryRun:    os.Getenv("DRY_RUN") == "true",
+		ServerURL:    getEnv("SERVER_URL", "http://localhost:8080"),
+		Since:        getEnv("SINCE", "24 hours ago"),
+		DryRun:       os.Getenv("DRY_RUN") == "true",
+		WithStats:    os.Getenv("WITH_STATS") == "true",
+		Watch:        os.Getenv("WATCH") == "true",
+		PollInterval: 5 * time.Minute,
+	}
+	if v := os.Getenv("POLL_INTERVAL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			config.PollInterval = d
+		}
 	}
 
 	// Determine root directory to scan
 	rootDir := "./"
-	if len(os.Args) > 1 {
-		rootDir = os.Args[1]
+	args := os.Args[1:]
+	for _, a := range args {
+		if a == "--watch" {
+			config.Watch = true
+			continue
+		}
+		rootDir = a
 	}
 
+	if config.Watch {
+		runWatch(rootDir, config)
+		return
+	}
+
+	runOnce(rootDir, config)
+}
+
+// runOnce scans rootDir for git repos, collects commits since config.Since,
+// and either prints them (DryRun) or uploads them once before exiting.
+func runOnce(rootDir string, config Config) {
 	fmt.Printf("🔍 Scanning for git repos in: %s\n", rootDir)
 	fmt.Printf("   Looking for commits since: %s\n", config.Since)
 
-	// Find all repositories
 	repos, err := findGitRepos(rootDir)
 	if err != nil {
 		logError(err)
@@ -60,10 +100,8 @@ func main() {
 	fmt.Printf("📁 Found %d git repositories\n", len(repos))
 
 	var allContributions []Contribution
-
-	// Extract commits from each repo
 	for _, repoPath := range repos {
-		commits, err := getGitCommits(repoPath, config.Since)
+		commits, err := getGitCommits(repoPath, config.Since, config.WithStats)
 		if err != nil {
 			fmt.Printf("⚠️  Could not read repo %s: %v\n", repoPath, err)
 			continue
@@ -81,25 +119,159 @@ func main() {
 		return
 	}
 
-	// Output or send data
 	if config.DryRun {
 		jsonData, _ := json.MarshalIndent(allContributions, "", "  ")
 		fmt.Println("\n📄 Dry run output (JSON):")
 		fmt.Println(string(jsonData))
-	} else {
-		err := sendToServer(config.ServerURL, allContributions)
+		return
+	}
+
+	if err := sendToServer(config.ServerURL, allContributions); err != nil {
+		fmt.Printf("❌ Upload failed: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("🎉 Successfully uploaded %d contributions to %s\n", len(allContributions), config.ServerURL)
+}
+
+// runWatch turns the agent into a long-lived daemon: it polls each known
+// repo for commits newer than the last one it uploaded, tracking progress
+// in a state file so a restart doesn't re-send history. It rescans rootDir
+// for newly created repos on rescanInterval and backs off exponentially
+// while the server is unreachable.
+func runWatch(rootDir string, config Config) {
+	fmt.Printf("👀 Watching for git repos in: %s (poll every %s)\n", rootDir, config.PollInterval)
+
+	statePath, err := defaultStatePath()
+	if err != nil {
+		logError(err)
+		os.Exit(1)
+	}
+
+	state, err := loadState(statePath)
+	if err != nil {
+		fmt.Printf("⚠️  Could not read state file, starting fresh: %v\n", err)
+		state = AgentState{}
+	}
+
+	repos, err := findGitRepos(rootDir)
+	if err != nil {
+		logError(err)
+		os.Exit(1)
+	}
+	fmt.Printf("📁 Found %d git repositories\n", len(repos))
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	pollTimer := time.NewTimer(0) // fire immediately on start
+	defer pollTimer.Stop()
+	rescanTimer := time.NewTimer(rescanInterval)
+	defer rescanTimer.Stop()
+
+	backoff := config.PollInterval
+
+	for {
+		select {
+		case <-ctx.Done():
+			fmt.Println("\n🛑 Shutting down, saving state...")
+			if err := saveState(statePath, state); err != nil {
+				logError(err)
+			}
+			return
+
+		case <-rescanTimer.C:
+			newRepos, err := findGitRepos(rootDir)
+			if err != nil {
+				logError(err)
+			} else {
+				fmt.Printf("🔄 Rescanned: %d git repositories\n", len(newRepos))
+				repos = newRepos
+			}
+			rescanTimer.Reset(rescanInterval)
+
+		case <-pollTimer.C:
+			ok := pollOnce(repos, config, state)
+			if err := saveState(statePath, state); err != nil {
+				logError(err)
+			}
+
+			if ok {
+				backoff = config.PollInterval
+			} else if backoff < 30*time.Minute {
+				backoff *= 2
+			}
+			pollTimer.Reset(backoff)
+		}
+	}
+}
+
+// pollOnce fetches each repo, uploads any commits newer than state's last
+// recorded hash, and advances state on success. It returns false if the
+// upload to the server failed, so the caller can back off.
+func pollOnce(repos []string, config Config, state AgentState) bool {
+	var allContributions []Contribution
+	newHashes := make(map[string]string)
+
+	for _, repoPath := range repos {
+		if err := gitFetchAll(repoPath); err != nil {
+			fmt.Printf("⚠️  Could not fetch %s: %v\n", filepath.Base(repoPath), err)
+		}
+
+		lastHash := state[repoPath]
+		commits, err := getNewCommits(repoPath, lastHash, config.Since, config.WithStats)
 		if err != nil {
-			fmt.Printf("❌ Upload failed: %v\n", err)
-			os.Exit(1)
+			fmt.Printf("⚠️  Could not read repo %s: %v\n", repoPath, err)
+			continue
 		}
-		fmt.Printf("🎉 Successfully uploaded %d contributions to %s\n", len(allContributions), config.ServerURL)
+		if len(commits) == 0 {
+			continue
+		}
+
+		fmt.Printf("   📝 %s: %d new commits\n", filepath.Base(repoPath), len(commits))
+		allContributions = append(allContributions, commits...)
+		// getGitCommits/getNewCommits return newest-first, so commits[0] is HEAD.
+		newHashes[repoPath] = commits[0].MetaData.Hash
+	}
+
+	if len(allContributions) == 0 {
+		return true
+	}
+
+	if config.DryRun {
+		jsonData, _ := json.MarshalIndent(allContributions, "", "  ")
+		fmt.Println(string(jsonData))
+		for repoPath, hash := range newHashes {
+			state[repoPath] = hash
+		}
+		return true
+	}
+
+	if err := sendToServer(config.ServerURL, allContributions); err != nil {
+		fmt.Printf("❌ Upload failed, will retry with backoff: %v\n", err)
+		return false
+	}
+
+	for repoPath, hash := range newHashes {
+		state[repoPath] = hash
 	}
+	fmt.Printf("🎉 Uploaded %d contributions\n", len(allContributions))
+	return true
+}
+
+// gitFetchAll runs `git fetch --all --quiet` to update the repo's
+// remote-tracking refs. It does not move HEAD, and getNewCommits reads
+// lastHash..HEAD, so a commit that exists only on a remote branch is not
+// picked up until it reaches the local HEAD.
+func gitFetchAll(repoPath string) error {
+	cmd := exec.Command("git", "fetch", "--all", "--quiet")
+	cmd.Dir = repoPath
+	return cmd.Run()
 }
 
 // findGitRepos walks the directory tree looking for .git folders
 func findGitRepos(root string) ([]string, error) {
 	var repos []string
-	
+
 	// Resolve absolute path
 	absRoot, err := filepath.Abs(root)
 	if err != nil {
@@ -130,11 +302,37 @@ func findGitRepos(root string) ([]string, error) {
 	return repos, err
 }
 
-// getGitCommits runs the git log command in the specific folder
-func getGitCommits(repoPath, since string) ([]Contribution, error) {
+// getGitCommits runs the git log command in the specific folder. When
+// withStats is set it additionally sums --numstat additions/deletions per
+// commit into MetaData.Stats.
+func getGitCommits(repoPath, since string, withStats bool) ([]Contribution, error) {
 	// Format: Hash|ISO-Date|Email|Subject
 	// %H = Hash, %aI = Author Date (ISO 8601), %ae = Email, %s = Subject
-	cmd := exec.Command("git", "log", "--since="+since, "--pretty=format:%H|%aI|%ae|%s")
+	args := []string{"log", "--since=" + since, "--pretty=format:%H|%aI|%ae|%s"}
+	if withStats {
+		args = []string{"log", "--numstat", "--since=" + since, "--pretty=format:%H|%aI|%ae|%s"}
+	}
+	return runGitLog(repoPath, args, withStats)
+}
+
+// getNewCommits returns commits made after lastHash. If lastHash is empty
+// (a repo watch mode has never seen before), it falls back to since, the
+// same window a one-shot run would use.
+func getNewCommits(repoPath, lastHash, since string, withStats bool) ([]Contribution, error) {
+	rangeSpec := "--since=" + since
+	if lastHash != "" {
+		rangeSpec = lastHash + "..HEAD"
+	}
+
+	args := []string{"log", rangeSpec, "--pretty=format:%H|%aI|%ae|%s"}
+	if withStats {
+		args = []string{"log", "--numstat", rangeSpec, "--pretty=format:%H|%aI|%ae|%s"}
+	}
+	return runGitLog(repoPath, args, withStats)
+}
+
+func runGitLog(repoPath string, args []string, withStats bool) ([]Contribution, error) {
+	cmd := exec.Command("git", args...)
 	cmd.Dir = repoPath
 
 	out, err := cmd.Output()
@@ -143,11 +341,18 @@ func getGitCommits(repoPath, since string) ([]Contribution, error) {
 		return []Contribution{}, nil
 	}
 
-	var contributions []Contribution
-	lines := strings.Split(string(out), "\n")
 	repoName := filepath.Base(repoPath)
+	if withStats {
+		return parseCommitsWithStats(string(out), repoName), nil
+	}
+	return parseCommits(string(out), repoName), nil
+}
 
-	for _, line := range lines {
+// parseCommits parses plain "Hash|ISO-Date|Email|Subject" lines.
+func parseCommits(out, repoName string) []Contribution {
+	var contributions []Contribution
+
+	for _, line := range strings.Split(out, "\n") {
 		if strings.TrimSpace(line) == "" {
 			continue
 		}
@@ -156,13 +361,12 @@ func getGitCommits(repoPath, since string) ([]Contribution, error) {
 			continue
 		}
 
-		// Parse timestamp
 		t, err := time.Parse(time.RFC3339, parts[1])
 		if err != nil {
 			continue
 		}
 
-		c := Contribution{
+		contributions = append(contributions, Contribution{
 			Source:    "git",
 			Context:   repoName,
 			Timestamp: t,
@@ -171,11 +375,71 @@ func getGitCommits(repoPath, since string) ([]Contribution, error) {
 				Author:  parts[2],
 				Message: truncateString(parts[3], 100),
 			},
+		})
+	}
+
+	return contributions
+}
+
+// parseCommitsWithStats parses the same header lines interleaved with
+// --numstat blocks ("additions\tdeletions\tpath" per changed file,
+// terminated by a blank line) and sums each commit's totals into Stats.
+func parseCommitsWithStats(out, repoName string) []Contribution {
+	var contributions []Contribution
+	var current *Contribution
+
+	for _, line := range strings.Split(out, "\n") {
+		if strings.Contains(line, "|") && strings.Count(line, "|") >= 3 && !strings.Contains(line, "\t") {
+			parts := strings.SplitN(line, "|", 4)
+			if len(parts) < 4 {
+				continue
+			}
+			t, err := time.Parse(time.RFC3339, parts[1])
+			if err != nil {
+				continue
+			}
+
+			if current != nil {
+				contributions = append(contributions, *current)
+			}
+			current = &Contribution{
+				Source:    "git",
+				Context:   repoName,
+				Timestamp: t,
+				MetaData: MetaData{
+					Hash:    parts[0],
+					Author:  parts[2],
+					Message: truncateString(parts[3], 100),
+					Stats:   &Stats{},
+				},
+			}
+			continue
+		}
+
+		if strings.TrimSpace(line) == "" || current == nil {
+			continue
 		}
-		contributions = append(contributions, c)
+
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) < 3 {
+			continue
+		}
+		// Binary files report "-" instead of a number; skip those.
+		added, errA := strconv.Atoi(fields[0])
+		deleted, errD := strconv.Atoi(fields[1])
+		if errA != nil || errD != nil {
+			continue
+		}
+		current.MetaData.Stats.Additions += added
+		current.MetaData.Stats.Deletions += deleted
+		current.MetaData.Stats.FilesChanged++
+	}
+
+	if current != nil {
+		contributions = append(contributions, *current)
 	}
 
-	return contributions, nil
+	return contributions
 }
 
 // sendToServer posts contributions to the API
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// AgentState maps a repo's absolute path to the last commit hash the agent
+// has already uploaded for it.
+type AgentState map[string]string
+
+func defaultStatePath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(configDir, "contribution-graph")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "agent-state.json"), nil
+}
+
+func loadState(path string) (AgentState, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return AgentState{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var state AgentState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// saveState writes state atomically: it writes to a temp file in the same
+// directory then renames it over the target, so a crash mid-write never
+// leaves a corrupt state file behind.
+func saveState(path string, state AgentState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,58 @@
+// Package forge defines the pluggable interface that every supported code
+// forge (GitHub, GitLab, Gerrit, SourceHut, ...) implements, plus a small
+// registry so the importer binary can look sources up by name.
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Contribution mirrors the server's unified event structure so importers can
+// marshal results straight into a POST to /api/contributions.
+type Contribution struct {
+	Source    string          `json:"source"`
+	Context   string          `json:"context"`
+	Timestamp time.Time       `json:"timestamp"`
+	MetaData  json.RawMessage `json:"metadata"`
+}
+
+// Config carries the credentials and endpoints a Source needs. Not every
+// field applies to every forge; sources ignore what they don't use.
+type Config struct {
+	User    string `yaml:"user"`
+	Token   string `yaml:"token"`
+	BaseURL string `yaml:"base_url"`
+}
+
+// Source fetches contributions for a user since a given time.
+type Source interface {
+	Fetch(ctx context.Context, user string, since time.Time) ([]Contribution, error)
+}
+
+// Factory builds a Source from its config. Concrete forges register a
+// Factory under their name in an init() func, the same way database/sql
+// drivers register themselves.
+type Factory func(cfg Config) (Source, error)
+
+var registry = map[string]Factory{}
+
+// Register makes a forge implementation available under name. It panics on
+// duplicate registration, mirroring database/sql.Register.
+func Register(name string, f Factory) {
+	if _, exists := registry[name]; exists {
+		panic(fmt.Sprintf("forge: Register called twice for source %q", name))
+	}
+	registry[name] = f
+}
+
+// New looks up the named forge and builds a Source from cfg.
+func New(name string, cfg Config) (Source, error) {
+	f, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("forge: unknown source %q", name)
+	}
+	return f(cfg)
+}
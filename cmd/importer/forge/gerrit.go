@@ -0,0 +1,92 @@
+package forge
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+func init() {
+	Register("gerrit", newGerrit)
+}
+
+// gerritTimeFormat is the timestamp layout Gerrit's REST API uses for
+// created/updated fields, e.g. "2021-05-04 12:34:56.000000000".
+const gerritTimeFormat = "2006-01-02 15:04:05.000000000"
+
+// gerritXSSIPrefix is prepended to every Gerrit REST response to prevent
+// it from being executed as a <script> tag; it must be stripped before
+// the body is valid JSON.
+const gerritXSSIPrefix = ")]}'"
+
+type gerritSource struct {
+	baseURL string
+	token   string
+}
+
+func newGerrit(cfg Config) (Source, error) {
+	if cfg.BaseURL == "" {
+		return nil, fmt.Errorf("gerrit: base_url is required")
+	}
+	return &gerritSource{baseURL: cfg.BaseURL, token: cfg.Token}, nil
+}
+
+type gerritChange struct {
+	ChangeID string `json:"change_id"`
+	Project  string `json:"project"`
+	Subject  string `json:"subject"`
+	Created  string `json:"created"`
+	Updated  string `json:"updated"`
+}
+
+type gerritMetaData struct {
+	ChangeID string `json:"change_id"`
+	Subject  string `json:"subject"`
+}
+
+// Fetch queries /changes/?q=owner:self+after:<since>&o=MESSAGES and maps
+// each returned change into a contribution scoped to its project.
+func (s *gerritSource) Fetch(ctx context.Context, user string, since time.Time) ([]Contribution, error) {
+	url := fmt.Sprintf("%s/a/changes/?q=owner:self+after:%s&o=MESSAGES", s.baseURL, since.Format("2006-01-02"))
+
+	hdr := map[string]string{}
+	if s.token != "" {
+		creds := base64.StdEncoding.EncodeToString([]byte(user + ":" + s.token))
+		hdr["Authorization"] = "Basic " + creds
+	}
+
+	body, _, err := httpGet(url, hdr)
+	if err != nil {
+		return nil, fmt.Errorf("gerrit: %w", err)
+	}
+	body = bytes.TrimPrefix(body, []byte(gerritXSSIPrefix))
+
+	var changes []gerritChange
+	if err := json.Unmarshal(body, &changes); err != nil {
+		return nil, fmt.Errorf("gerrit: decoding response: %w", err)
+	}
+
+	var contributions []Contribution
+	for _, c := range changes {
+		t, err := time.Parse(gerritTimeFormat, c.Updated)
+		if err != nil {
+			continue
+		}
+
+		meta, _ := json.Marshal(gerritMetaData{
+			ChangeID: c.ChangeID,
+			Subject:  c.Subject,
+		})
+		contributions = append(contributions, Contribution{
+			Source:    "gerrit",
+			Context:   c.Project,
+			Timestamp: t,
+			MetaData:  meta,
+		})
+	}
+
+	return contributions, nil
+}
@@ -0,0 +1,128 @@
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+func init() {
+	Register("gitlab", newGitLab)
+}
+
+type gitLabSource struct {
+	baseURL string
+	token   string
+}
+
+func newGitLab(cfg Config) (Source, error) {
+	if cfg.Token == "" {
+		return nil, fmt.Errorf("gitlab: token is required")
+	}
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = "https://gitlab.com"
+	}
+	return &gitLabSource{baseURL: baseURL, token: cfg.Token}, nil
+}
+
+type gitLabEvent struct {
+	ActionName string `json:"action_name"`
+	CreatedAt  string `json:"created_at"`
+	ProjectID  int    `json:"project_id"`
+	TargetType string `json:"target_type"`
+}
+
+type gitLabMetaData struct {
+	Action string `json:"action"`
+	Target string `json:"target,omitempty"`
+}
+
+type gitLabProject struct {
+	PathWithNamespace string `json:"path_with_namespace"`
+}
+
+// projectPath resolves a numeric GitLab project id to its
+// "group/project" path via GET /projects/:id, using paths as a
+// per-Fetch-call cache since the same project recurs across events.
+func (s *gitLabSource) projectPath(paths map[int]string, id int) (string, error) {
+	if p, ok := paths[id]; ok {
+		return p, nil
+	}
+	url := fmt.Sprintf("%s/api/v4/projects/%d", s.baseURL, id)
+	body, _, err := httpGet(url, map[string]string{"PRIVATE-TOKEN": s.token})
+	if err != nil {
+		return "", fmt.Errorf("gitlab: resolving project %d: %w", id, err)
+	}
+	var p gitLabProject
+	if err := json.Unmarshal(body, &p); err != nil {
+		return "", fmt.Errorf("gitlab: decoding project %d: %w", id, err)
+	}
+	paths[id] = p.PathWithNamespace
+	return p.PathWithNamespace, nil
+}
+
+// Fetch pages through GET /users/:id/events?after=YYYY-MM-DD, following the
+// X-Next-Page response header, and maps push/merge_request/issue actions
+// into contributions scoped to their project path.
+func (s *gitLabSource) Fetch(ctx context.Context, user string, since time.Time) ([]Contribution, error) {
+	var contributions []Contribution
+	paths := make(map[int]string)
+	page := "1"
+
+	for page != "" {
+		url := fmt.Sprintf("%s/api/v4/users/%s/events?after=%s&page=%s&per_page=100",
+			s.baseURL, user, since.Format("2006-01-02"), page)
+
+		body, header, err := httpGet(url, map[string]string{"PRIVATE-TOKEN": s.token})
+		if err != nil {
+			return nil, fmt.Errorf("gitlab: %w", err)
+		}
+
+		var events []gitLabEvent
+		if err := json.Unmarshal(body, &events); err != nil {
+			return nil, fmt.Errorf("gitlab: decoding response: %w", err)
+		}
+		nextPage := header.Get("X-Next-Page")
+
+		for _, e := range events {
+			if !isTrackedGitLabAction(e.ActionName) {
+				continue
+			}
+			t, err := time.Parse(time.RFC3339, e.CreatedAt)
+			if err != nil {
+				continue
+			}
+
+			path, err := s.projectPath(paths, e.ProjectID)
+			if err != nil {
+				return nil, err
+			}
+
+			meta, _ := json.Marshal(gitLabMetaData{
+				Action: e.ActionName,
+				Target: e.TargetType,
+			})
+			contributions = append(contributions, Contribution{
+				Source:    "gitlab",
+				Context:   path,
+				Timestamp: t,
+				MetaData:  meta,
+			})
+		}
+
+		page = nextPage
+	}
+
+	return contributions, nil
+}
+
+func isTrackedGitLabAction(action string) bool {
+	switch action {
+	case "pushed to", "pushed new", "accepted", "opened", "created":
+		return true
+	default:
+		return false
+	}
+}
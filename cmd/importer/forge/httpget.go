@@ -0,0 +1,65 @@
+package forge
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/Tomer-Barak/contribution-graph/internal/httpcache"
+)
+
+// cache is shared by every forge implementation's GET requests. It starts
+// nil (uncached) until Configure is called by the importer binary.
+var cache *httpcache.Client
+
+// Configure wires an on-disk response cache with the given TTL into every
+// GET made through httpGet. A zero TTL disables caching.
+func Configure(ttl time.Duration) error {
+	c, err := httpcache.New(ttl)
+	if err != nil {
+		return err
+	}
+	cache = c
+	return nil
+}
+
+// httpGet performs a GET against u with the given headers, going through
+// the shared response cache when one has been configured. It returns the
+// response headers alongside the body so callers can still follow
+// pagination links on a cache hit.
+func httpGet(u string, hdr map[string]string) ([]byte, http.Header, error) {
+	if cache != nil {
+		return cache.Get(u, hdr)
+	}
+
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	for k, v := range hdr {
+		req.Header.Set(k, v)
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, nil, &httpStatusError{url: u, status: resp.StatusCode}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	return body, resp.Header, err
+}
+
+type httpStatusError struct {
+	url    string
+	status int
+}
+
+func (e *httpStatusError) Error() string {
+	return "forge: " + e.url + " returned status " + http.StatusText(e.status)
+}
@@ -0,0 +1,247 @@
+package forge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+func init() {
+	Register("sourcehut", newSourceHut)
+}
+
+type sourceHutSource struct {
+	token string
+}
+
+func newSourceHut(cfg Config) (Source, error) {
+	if cfg.Token == "" {
+		return nil, fmt.Errorf("sourcehut: token is required")
+	}
+	return &sourceHutSource{token: cfg.Token}, nil
+}
+
+const sourceHutReposQuery = `
+{
+  me {
+    repositories {
+      results {
+        name
+      }
+    }
+  }
+}
+`
+
+type sourceHutReposResponse struct {
+	Data struct {
+		Me struct {
+			Repositories struct {
+				Results []struct {
+					Name string `json:"name"`
+				} `json:"results"`
+			} `json:"repositories"`
+		} `json:"me"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+const sourceHutLogQuery = `
+query($name: String!, $cursor: Cursor) {
+  me {
+    repository(name: $name) {
+      log(cursor: $cursor) {
+        cursor
+        results {
+          id
+          message
+          timestamp
+        }
+      }
+    }
+  }
+}
+`
+
+type sourceHutLogResponse struct {
+	Data struct {
+		Me struct {
+			Repository struct {
+				Log struct {
+					Cursor  *string `json:"cursor"`
+					Results []struct {
+						ID        string `json:"id"`
+						Message   string `json:"message"`
+						Timestamp string `json:"timestamp"`
+					} `json:"results"`
+				} `json:"log"`
+			} `json:"repository"`
+		} `json:"me"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+type sourceHutMetaData struct {
+	CommitID string `json:"commit_id"`
+	Message  string `json:"message"`
+}
+
+// Fetch confirms the user against meta.sr.ht, then queries git.sr.ht for
+// their repositories and each repository's commit log.
+func (s *sourceHutSource) Fetch(ctx context.Context, user string, since time.Time) ([]Contribution, error) {
+	if err := s.verifyUser(ctx, user); err != nil {
+		return nil, err
+	}
+
+	body, err := s.graphQL(ctx, "https://git.sr.ht/query", sourceHutReposQuery, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var reposResp sourceHutReposResponse
+	if err := json.Unmarshal(body, &reposResp); err != nil {
+		return nil, fmt.Errorf("sourcehut: decoding repositories response: %w", err)
+	}
+	if len(reposResp.Errors) > 0 {
+		return nil, fmt.Errorf("sourcehut: API error: %s", reposResp.Errors[0].Message)
+	}
+
+	var contributions []Contribution
+	for _, repo := range reposResp.Data.Me.Repositories.Results {
+		commits, err := s.repoLog(ctx, repo.Name, since)
+		if err != nil {
+			return nil, err
+		}
+		contributions = append(contributions, commits...)
+	}
+
+	return contributions, nil
+}
+
+// repoLog pages through a single repository's commit log via the cursor
+// sourcehut hands back on each response, stopping once the log runs dry.
+// Commits older than since are skipped; they do not end the paging early.
+func (s *sourceHutSource) repoLog(ctx context.Context, name string, since time.Time) ([]Contribution, error) {
+	var contributions []Contribution
+	var cursor *string
+
+	for {
+		body, err := s.graphQL(ctx, "https://git.sr.ht/query", sourceHutLogQuery, map[string]interface{}{
+			"name":   name,
+			"cursor": cursor,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var logResp sourceHutLogResponse
+		if err := json.Unmarshal(body, &logResp); err != nil {
+			return nil, fmt.Errorf("sourcehut: decoding log response for %s: %w", name, err)
+		}
+		if len(logResp.Errors) > 0 {
+			return nil, fmt.Errorf("sourcehut: API error: %s", logResp.Errors[0].Message)
+		}
+
+		for _, commit := range logResp.Data.Me.Repository.Log.Results {
+			t, err := time.Parse(time.RFC3339, commit.Timestamp)
+			if err != nil || t.Before(since) {
+				continue
+			}
+
+			meta, _ := json.Marshal(sourceHutMetaData{
+				CommitID: commit.ID,
+				Message:  commit.Message,
+			})
+			contributions = append(contributions, Contribution{
+				Source:    "sourcehut",
+				Context:   name,
+				Timestamp: t,
+				MetaData:  meta,
+			})
+		}
+
+		cursor = logResp.Data.Me.Repository.Log.Cursor
+		if cursor == nil {
+			return contributions, nil
+		}
+	}
+}
+
+type sourceHutMeResponse struct {
+	Data struct {
+		Me struct {
+			CanonicalName string `json:"canonicalName"`
+		} `json:"me"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// verifyUser confirms the token belongs to the account named by user,
+// comparing against canonicalName with its leading "~" stripped since
+// callers pass bare usernames.
+func (s *sourceHutSource) verifyUser(ctx context.Context, user string) error {
+	body, err := s.graphQL(ctx, "https://meta.sr.ht/query", `{ me { canonicalName } }`, nil)
+	if err != nil {
+		return err
+	}
+
+	var meResp sourceHutMeResponse
+	if err := json.Unmarshal(body, &meResp); err != nil {
+		return fmt.Errorf("sourcehut: decoding meta.sr.ht response: %w", err)
+	}
+	if len(meResp.Errors) > 0 {
+		return fmt.Errorf("sourcehut: API error: %s", meResp.Errors[0].Message)
+	}
+
+	name := strings.TrimPrefix(meResp.Data.Me.CanonicalName, "~")
+	if name != user {
+		return fmt.Errorf("sourcehut: token belongs to %q, not %q", name, user)
+	}
+
+	return nil
+}
+
+func (s *sourceHutSource) graphQL(ctx context.Context, endpoint, query string, variables map[string]interface{}) ([]byte, error) {
+	payload := map[string]interface{}{"query": query}
+	if variables != nil {
+		payload["variables"] = variables
+	}
+	reqBody, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewBuffer(reqBody))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+s.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("sourcehut: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("sourcehut: API returned status %d", resp.StatusCode)
+	}
+
+	buf := new(bytes.Buffer)
+	if _, err := buf.ReadFrom(resp.Body); err != nil {
+		return nil, fmt.Errorf("sourcehut: reading response: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,148 @@
+// Command importer reads a YAML config listing one or more forge sources,
+// fetches each one's contributions since the configured `since` date (one
+// year ago when unset; no per-source last-run state is kept), and posts the
+// results to the contribution-graph server. It replaces the old
+// single-purpose github-importer binary now that forge/ supports multiple
+// providers.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Tomer-Barak/contribution-graph/cmd/importer/forge"
+)
+
+// ImporterConfig is the top-level YAML document.
+type ImporterConfig struct {
+	ServerURL string         `yaml:"server_url"`
+	Since     string         `yaml:"since"`
+	Sources   []SourceConfig `yaml:"sources"`
+}
+
+// SourceConfig describes one forge entry under `sources:`.
+type SourceConfig struct {
+	Type    string `yaml:"type"`
+	User    string `yaml:"user"`
+	Token   string `yaml:"token"`
+	BaseURL string `yaml:"base_url"`
+}
+
+func main() {
+	cacheTTL := flag.Duration("cache-ttl", time.Hour, "how long to reuse cached forge API responses (0 disables caching)")
+	flag.Parse()
+
+	configPath := getEnv("IMPORTER_CONFIG", "importer.yaml")
+	if flag.NArg() > 0 {
+		configPath = flag.Arg(0)
+	}
+
+	if err := forge.Configure(*cacheTTL); err != nil {
+		fmt.Printf("❌ Error setting up response cache: %v\n", err)
+		os.Exit(1)
+	}
+
+	cfg, err := loadConfig(configPath)
+	if err != nil {
+		fmt.Printf("❌ Error loading config %s: %v\n", configPath, err)
+		os.Exit(1)
+	}
+
+	since, err := parseSince(cfg.Since)
+	if err != nil {
+		fmt.Printf("❌ Error parsing 'since': %v\n", err)
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+	var total int
+
+	for _, sc := range cfg.Sources {
+		fmt.Printf("🚀 Fetching contributions from %s (%s)\n", sc.Type, sc.User)
+
+		src, err := forge.New(sc.Type, forge.Config{
+			User:    sc.User,
+			Token:   sc.Token,
+			BaseURL: sc.BaseURL,
+		})
+		if err != nil {
+			fmt.Printf("❌ %s: %v\n", sc.Type, err)
+			continue
+		}
+
+		contributions, err := src.Fetch(ctx, sc.User, since)
+		if err != nil {
+			fmt.Printf("❌ %s: %v\n", sc.Type, err)
+			continue
+		}
+
+		fmt.Printf("📊 %s: %d contributions found\n", sc.Type, len(contributions))
+		if len(contributions) == 0 {
+			continue
+		}
+
+		if err := postContributions(cfg.ServerURL, contributions); err != nil {
+			fmt.Printf("❌ %s: uploading: %v\n", sc.Type, err)
+			continue
+		}
+		total += len(contributions)
+	}
+
+	fmt.Printf("🎉 Imported %d contributions across %d sources\n", total, len(cfg.Sources))
+}
+
+func loadConfig(path string) (*ImporterConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg ImporterConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing YAML: %w", err)
+	}
+	if cfg.ServerURL == "" {
+		cfg.ServerURL = "http://localhost:8080/api/contributions"
+	}
+	return &cfg, nil
+}
+
+func parseSince(since string) (time.Time, error) {
+	if since == "" {
+		return time.Now().AddDate(-1, 0, 0), nil
+	}
+	return time.Parse("2006-01-02", since)
+}
+
+func postContributions(serverURL string, contributions []forge.Contribution) error {
+	jsonData, err := json.Marshal(contributions)
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.Post(serverURL, "application/json", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("server returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,242 @@
+// Command mailimporter scrapes a Mailman/Pipermail-style archive
+// (monthly gzipped mbox files at <list-url>/YYYY-Month.txt.gz) for
+// messages sent by a configured set of author addresses, and uploads
+// them to the contribution-graph server as "mailinglist" contributions.
+// This lets maintainers who review patches over email see that work in
+// the heatmap alongside commits.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Tomer-Barak/contribution-graph/internal/httpcache"
+)
+
+// Contribution represents the unified event structure
+type Contribution struct {
+	Source    string   `json:"source"`
+	Context   string   `json:"context"`
+	Timestamp string   `json:"timestamp"`
+	MetaData  MetaData `json:"metadata"`
+}
+
+type MetaData struct {
+	Subject   string `json:"subject"`
+	MessageID string `json:"message_id"`
+	InReplyTo string `json:"in_reply_to,omitempty"`
+	ListURL   string `json:"list_url"`
+}
+
+// Config holds the importer configuration
+type Config struct {
+	ListURL   string
+	ListName  string
+	Emails    []string
+	From      time.Time
+	To        time.Time
+	ServerURL string
+	CacheTTL  time.Duration
+	DryRun    bool
+}
+
+func main() {
+	config, err := loadConfig()
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	cache, err := httpcache.New(config.CacheTTL)
+	if err != nil {
+		fmt.Printf("❌ Error setting up response cache: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("🔍 Scanning %s for messages from %v\n", config.ListURL, config.Emails)
+	fmt.Printf("   Months: %s to %s\n", config.From.Format("2006-01"), config.To.Format("2006-01"))
+
+	var contributions []Contribution
+	months := monthsInRange(config.From, config.To)
+	for i, month := range months {
+		latest := i == len(months)-1
+		msgs, err := fetchMonth(cache, config.ListURL, month, latest)
+		if err != nil {
+			fmt.Printf("⚠️  %s: %v\n", month.Format("2006-01"), err)
+			continue
+		}
+
+		matched := 0
+		for _, msg := range msgs {
+			if msg.Date.IsZero() || !matchesAuthor(msg.From, config.Emails) {
+				continue
+			}
+			contributions = append(contributions, Contribution{
+				Source:    "mailinglist",
+				Context:   config.ListName,
+				Timestamp: msg.Date.Format(time.RFC3339),
+				MetaData: MetaData{
+					Subject:   msg.Subject,
+					MessageID: msg.MessageID,
+					InReplyTo: msg.InReplyTo,
+					ListURL:   config.ListURL,
+				},
+			})
+			matched++
+		}
+		fmt.Printf("   📝 %s: %d messages, %d from tracked authors\n", month.Format("2006-01"), len(msgs), matched)
+	}
+
+	fmt.Printf("\n✅ Total contributions found: %d\n", len(contributions))
+	if len(contributions) == 0 {
+		fmt.Println("No new contributions to sync.")
+		return
+	}
+
+	if config.DryRun {
+		jsonData, _ := json.MarshalIndent(contributions, "", "  ")
+		fmt.Println("\n📄 Dry run output (JSON):")
+		fmt.Println(string(jsonData))
+		return
+	}
+
+	if err := sendToServer(config.ServerURL, contributions); err != nil {
+		fmt.Printf("❌ Upload failed: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("🎉 Successfully uploaded %d contributions to %s\n", len(contributions), config.ServerURL)
+}
+
+func loadConfig() (Config, error) {
+	listURL := strings.TrimSuffix(os.Getenv("LIST_URL"), "/")
+	if listURL == "" {
+		return Config{}, fmt.Errorf("LIST_URL environment variable is required")
+	}
+
+	emailsCSV := os.Getenv("AUTHOR_EMAILS")
+	if emailsCSV == "" {
+		return Config{}, fmt.Errorf("AUTHOR_EMAILS environment variable is required (comma-separated)")
+	}
+	var emails []string
+	for _, e := range strings.Split(emailsCSV, ",") {
+		if e = strings.ToLower(strings.TrimSpace(e)); e != "" {
+			emails = append(emails, e)
+		}
+	}
+
+	to := time.Now().UTC()
+	if v := os.Getenv("TO"); v != "" {
+		t, err := time.Parse("2006-01", v)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid TO %q: %w", v, err)
+		}
+		to = t
+	}
+
+	from := to.AddDate(-1, 0, 0)
+	if v := os.Getenv("FROM"); v != "" {
+		t, err := time.Parse("2006-01", v)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid FROM %q: %w", v, err)
+		}
+		from = t
+	}
+
+	cacheTTL := 6 * time.Hour
+	if v := os.Getenv("CACHE_TTL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid CACHE_TTL %q: %w", v, err)
+		}
+		cacheTTL = d
+	}
+
+	parts := strings.Split(listURL, "/")
+	listName := parts[len(parts)-1]
+	if v := os.Getenv("LIST_NAME"); v != "" {
+		listName = v
+	}
+
+	return Config{
+		ListURL:   listURL,
+		ListName:  listName,
+		Emails:    emails,
+		From:      from,
+		To:        to,
+		ServerURL: getEnv("SERVER_URL", "http://localhost:8080"),
+		CacheTTL:  cacheTTL,
+		DryRun:    os.Getenv("DRY_RUN") == "true",
+	}, nil
+}
+
+// matchesAuthor compares the message's From address against the
+// configured list. from has already been deobfuscated by the time it gets
+// here (see deobfuscateAddress), so a normal RFC 5322 parse extracts the
+// bare address; if parsing fails (a header mangled beyond what
+// deobfuscation fixes), it falls back to a substring match on the raw
+// header rather than dropping the message entirely.
+func matchesAuthor(from string, emails []string) bool {
+	addr := strings.ToLower(from)
+	if parsed, err := mail.ParseAddress(from); err == nil {
+		addr = strings.ToLower(parsed.Address)
+		for _, e := range emails {
+			if addr == e {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, e := range emails {
+		if strings.Contains(addr, e) {
+			return true
+		}
+	}
+	return false
+}
+
+// monthsInRange returns the first day of every month from `from` to `to`,
+// inclusive of both ends.
+func monthsInRange(from, to time.Time) []time.Time {
+	var months []time.Time
+	cur := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(to.Year(), to.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for !cur.After(end) {
+		months = append(months, cur)
+		cur = cur.AddDate(0, 1, 0)
+	}
+	return months
+}
+
+// sendToServer posts contributions to the API
+func sendToServer(serverURL string, contributions []Contribution) error {
+	jsonData, err := json.Marshal(contributions)
+	if err != nil {
+		return err
+	}
+
+	url := strings.TrimSuffix(serverURL, "/") + "/api/contributions"
+	resp, err := http.Post(url, "application/json", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("server returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Tomer-Barak/contribution-graph/internal/httpcache"
+)
+
+// mailMessage is the subset of mbox message headers we care about.
+type mailMessage struct {
+	From      string
+	Subject   string
+	MessageID string
+	InReplyTo string
+	Date      time.Time
+}
+
+// fetchMonth downloads and parses one monthly Pipermail archive
+// (<listURL>/YYYY-Month.txt.gz) through the shared response cache. A
+// missing archive (a month with no traffic yet, or the future) comes back
+// as an error like any other failed request; main logs it and moves on to
+// the next month. latest marks the in-progress month, whose archive
+// keeps growing as the list receives mail; it always revalidates against
+// the origin instead of trusting the cache's TTL, so completed months
+// (which never change again) are the only ones served straight from disk.
+func fetchMonth(cache *httpcache.Client, listURL string, month time.Time, latest bool) ([]mailMessage, error) {
+	url := fmt.Sprintf("%s/%d-%s.txt.gz", listURL, month.Year(), month.Month().String())
+
+	get := cache.Get
+	if latest {
+		get = cache.Refresh
+	}
+	body, _, err := get(url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("decompressing archive: %w", err)
+	}
+	defer gz.Close()
+
+	raw, err := io.ReadAll(gz)
+	if err != nil {
+		return nil, fmt.Errorf("reading archive: %w", err)
+	}
+
+	return parseMbox(raw), nil
+}
+
+// parseMbox splits a Pipermail mbox file on envelope "From " lines (the
+// mbox message separator; body lines starting with "From " are quoted with
+// a leading ">" by mbox writers, so this is unambiguous) and parses each
+// block's headers.
+func parseMbox(raw []byte) []mailMessage {
+	var messages []mailMessage
+	var current bytes.Buffer
+
+	flush := func() {
+		if current.Len() == 0 {
+			return
+		}
+		if msg, err := parseMessage(current.Bytes()); err == nil {
+			messages = append(messages, msg)
+		}
+		current.Reset()
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(raw))
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "From ") {
+			flush()
+			continue // drop the envelope line itself, it isn't a header
+		}
+		current.WriteString(line)
+		current.WriteString("\n")
+	}
+	flush()
+
+	return messages
+}
+
+func parseMessage(block []byte) (mailMessage, error) {
+	m, err := mail.ReadMessage(bytes.NewReader(block))
+	if err != nil {
+		return mailMessage{}, err
+	}
+
+	date, err := m.Header.Date()
+	if err != nil {
+		date = time.Time{}
+	}
+
+	return mailMessage{
+		From:      deobfuscateAddress(m.Header.Get("From")),
+		Subject:   m.Header.Get("Subject"),
+		MessageID: strings.Trim(m.Header.Get("Message-Id"), "<>"),
+		InReplyTo: strings.Trim(m.Header.Get("In-Reply-To"), "<>"),
+		Date:      date,
+	}, nil
+}
+
+// obscuredAt matches the " at " Mailman/Pipermail's default
+// obscure_addresses setting substitutes for "@" in archived From headers,
+// to deter address harvesters.
+var obscuredAt = regexp.MustCompile(`(?i)\s+at\s+`)
+
+// deobfuscateAddress reverses that substitution so the address is usable
+// again by net/mail and by matchesAuthor's comparison.
+func deobfuscateAddress(raw string) string {
+	return obscuredAt.ReplaceAllString(raw, "@")
+}
@@ -0,0 +1,352 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// queueItem is one contribution tagged with the batch it arrived in, both
+// in memory and as it's persisted to the spill log.
+type queueItem struct {
+	BatchID string `json:"batch_id"`
+	Contribution
+}
+
+// BatchStatus tracks how many of a batch's events have been committed, for
+// GET /api/batches/:id polling. updatedAt (unix nano) is bumped every time
+// Processed or Done changes, so the reaper can tell a long-finished batch
+// from one that's still being polled.
+type BatchStatus struct {
+	Total     int32 `json:"total"`
+	Processed int32 `json:"processed"`
+	Done      bool  `json:"done"`
+
+	updatedAt int64
+}
+
+func (s *BatchStatus) touch() {
+	atomic.StoreInt64(&s.updatedAt, time.Now().UnixNano())
+}
+
+// batchStatusTTL is how long a finished batch's status is kept around for
+// GET /api/batches/:id polling before the reaper evicts it. Without this, a
+// long-running server accumulates one BatchStatus per POST forever.
+const batchStatusTTL = 15 * time.Minute
+
+// batchReapInterval is how often the reaper sweeps for expired statuses.
+const batchReapInterval = 5 * time.Minute
+
+// IngestQueue decouples handlePostContribution from SQLite: POSTs enqueue
+// events and return immediately, while a single writer goroutine drains the
+// queue in batches so concurrent posts don't serialize on a transaction
+// each. Every enqueued event is spilled to disk first so a crash between
+// enqueue and commit doesn't lose it.
+type IngestQueue struct {
+	ch         chan queueItem
+	batchSize  int
+	flushEvery time.Duration
+
+	spillMu   sync.Mutex
+	spillFile *os.File
+	unflushed int64 // count of spilled events not yet committed, guards compaction
+
+	batches  sync.Map // batchID -> *BatchStatus
+	batchSeq int64
+}
+
+// NewIngestQueue opens (or creates) the spill log at spillPath, replays any
+// events left over from a previous crash, and starts the writer and reaper
+// goroutines.
+func NewIngestQueue(spillPath string, maxLen, batchSize int, flushEvery time.Duration) (*IngestQueue, error) {
+	q := &IngestQueue{
+		ch:         make(chan queueItem, maxLen),
+		batchSize:  batchSize,
+		flushEvery: flushEvery,
+	}
+
+	pending, err := readSpillLog(spillPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading queue spill log: %w", err)
+	}
+
+	spillFile, err := os.OpenFile(spillPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("opening queue spill log: %w", err)
+	}
+	q.spillFile = spillFile
+
+	go q.runWriter()
+	go q.reapBatches()
+
+	if len(pending) > 0 {
+		fmt.Printf("♻️  Replaying %d unflushed events from %s\n", len(pending), spillPath)
+		q.rewriteSpillLog(pending)
+		atomic.AddInt64(&q.unflushed, int64(len(pending)))
+		for _, item := range pending {
+			q.ch <- item
+		}
+	}
+
+	return q, nil
+}
+
+// reapBatches evicts finished batch statuses older than batchStatusTTL so
+// GET /api/batches/:id memory doesn't grow without bound over the life of
+// the server.
+func (q *IngestQueue) reapBatches() {
+	ticker := time.NewTicker(batchReapInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		cutoff := time.Now().Add(-batchStatusTTL).UnixNano()
+		q.batches.Range(func(key, value interface{}) bool {
+			status := value.(*BatchStatus)
+			if status.Done && atomic.LoadInt64(&status.updatedAt) < cutoff {
+				q.batches.Delete(key)
+			}
+			return true
+		})
+	}
+}
+
+func readSpillLog(path string) ([]queueItem, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var items []queueItem
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		var item queueItem
+		if err := json.Unmarshal(scanner.Bytes(), &item); err != nil {
+			continue // skip a partially-written line from a mid-write crash
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// NewBatchID returns a unique id for a freshly-enqueued POST and registers
+// its expected count so GET /api/batches/:id can report progress.
+func (q *IngestQueue) NewBatchID(total int) string {
+	seq := atomic.AddInt64(&q.batchSeq, 1)
+	id := fmt.Sprintf("%d-%d", time.Now().UnixNano(), seq)
+	status := &BatchStatus{Total: int32(total)}
+	status.touch()
+	q.batches.Store(id, status)
+	return id
+}
+
+// Enqueue spills contributions to disk under batchID, then pushes them onto
+// the channel for the writer goroutine to pick up. The spill write bumps
+// unflushed while still holding spillMu, so compactSpillLog (which takes
+// the same lock) can never see a truncatable log while an event is on disk
+// but not yet accounted for. The channel push happens outside the lock: for
+// a POST larger than the channel's capacity it can block until the writer
+// drains room, and holding spillMu across that block would also stall
+// compactSpillLog, which runs at the end of every flush.
+func (q *IngestQueue) Enqueue(batchID string, contributions []Contribution) error {
+	items := make([]queueItem, len(contributions))
+	for i, c := range contributions {
+		items[i] = queueItem{BatchID: batchID, Contribution: c}
+	}
+
+	q.spillMu.Lock()
+	for _, item := range items {
+		data, err := json.Marshal(item)
+		if err != nil {
+			continue
+		}
+		q.spillFile.Write(append(data, '\n'))
+	}
+	q.spillFile.Sync()
+	atomic.AddInt64(&q.unflushed, int64(len(items)))
+	q.spillMu.Unlock()
+
+	for _, item := range items {
+		q.ch <- item
+	}
+	return nil
+}
+
+// Status returns the current progress of batchID, if known.
+func (q *IngestQueue) Status(batchID string) (*BatchStatus, bool) {
+	v, ok := q.batches.Load(batchID)
+	if !ok {
+		return nil, false
+	}
+	return v.(*BatchStatus), true
+}
+
+// runWriter drains the channel in batches of up to batchSize events or
+// every flushEvery, whichever comes first, committing each batch inside a
+// single transaction.
+func (q *IngestQueue) runWriter() {
+	var batch []queueItem
+	timer := time.NewTimer(q.flushEvery)
+	defer timer.Stop()
+
+	for {
+		select {
+		case item, ok := <-q.ch:
+			if !ok {
+				q.flush(batch)
+				return
+			}
+			batch = append(batch, item)
+			if len(batch) >= q.batchSize {
+				q.flush(batch)
+				batch = nil
+				drainTimer(timer)
+				timer.Reset(q.flushEvery)
+			}
+
+		case <-timer.C:
+			if len(batch) > 0 {
+				q.flush(batch)
+				batch = nil
+			}
+			timer.Reset(q.flushEvery)
+		}
+	}
+}
+
+func drainTimer(t *time.Timer) {
+	if !t.Stop() {
+		select {
+		case <-t.C:
+		default:
+		}
+	}
+}
+
+// flushMaxAttempts bounds the in-process retries for a transient flush
+// failure (e.g. the database is momentarily locked). A batch that still
+// fails after every attempt is left counted in unflushed: the spill log
+// keeps it on disk uncompacted, so a restart replays it instead of it
+// being silently dropped.
+const flushMaxAttempts = 3
+
+// flush commits one batch inside a single transaction and advances every
+// affected batch's processed count. Once every event handed to the writer
+// so far has actually been committed, unflushed drops to zero and it's a
+// safe point to compact the spill log.
+func (q *IngestQueue) flush(batch []queueItem) {
+	if len(batch) == 0 {
+		return
+	}
+
+	var perBatch map[string]int32
+	var err error
+	for attempt := 1; attempt <= flushMaxAttempts; attempt++ {
+		perBatch, err = q.tryFlush(batch)
+		if err == nil {
+			break
+		}
+		fmt.Printf("❌ Queue flush (attempt %d/%d): %v\n", attempt, flushMaxAttempts, err)
+		if attempt < flushMaxAttempts {
+			time.Sleep(time.Duration(attempt) * 100 * time.Millisecond)
+		}
+	}
+	if err != nil {
+		fmt.Printf("❌ Queue flush: giving up on %d events after %d attempts; they remain in the spill log for replay\n", len(batch), flushMaxAttempts)
+		return
+	}
+
+	for batchID, n := range perBatch {
+		if v, ok := q.batches.Load(batchID); ok {
+			status := v.(*BatchStatus)
+			processed := atomic.AddInt32(&status.Processed, n)
+			if processed >= status.Total {
+				status.Done = true
+			}
+			status.touch()
+		}
+	}
+
+	atomic.AddInt64(&q.unflushed, -int64(len(batch)))
+	q.compactSpillLog()
+}
+
+// tryFlush makes a single attempt at committing batch inside one
+// transaction, returning how many events landed in each affected batchID.
+func (q *IngestQueue) tryFlush(batch []queueItem) (map[string]int32, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	stmt, err := tx.Prepare(`
+		INSERT OR IGNORE INTO events (source, context, timestamp, metadata)
+		VALUES (?, ?, ?, ?)
+	`)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	perBatch := make(map[string]int32)
+	for _, item := range batch {
+		metaString := string(item.MetaData)
+		if metaString == "" {
+			metaString = "{}"
+		}
+		if _, err := stmt.Exec(item.Source, item.Context, item.Timestamp, metaString); err == nil {
+			perBatch[item.BatchID]++
+		}
+	}
+	stmt.Close()
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return perBatch, nil
+}
+
+// compactSpillLog truncates the spill log once every event appended so far
+// has been committed. unflushed (rather than len(q.ch)) is the source of
+// truth for that: it's bumped under spillMu by Enqueue before the event
+// ever reaches the channel, and only brought back down by a successful
+// flush, so it stays nonzero for as long as an event is on disk but not
+// yet durably committed — including while it's still sitting in the
+// channel or a failed flush is being retried.
+func (q *IngestQueue) compactSpillLog() {
+	q.spillMu.Lock()
+	defer q.spillMu.Unlock()
+
+	if atomic.LoadInt64(&q.unflushed) != 0 {
+		return
+	}
+	if err := q.spillFile.Truncate(0); err != nil {
+		return
+	}
+	q.spillFile.Seek(0, 0)
+}
+
+func (q *IngestQueue) rewriteSpillLog(items []queueItem) {
+	q.spillMu.Lock()
+	defer q.spillMu.Unlock()
+
+	q.spillFile.Truncate(0)
+	q.spillFile.Seek(0, 0)
+	for _, item := range items {
+		data, err := json.Marshal(item)
+		if err != nil {
+			continue
+		}
+		q.spillFile.Write(append(data, '\n'))
+	}
+	q.spillFile.Sync()
+}
@@ -7,7 +7,10 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	_ "modernc.org/sqlite"
@@ -21,7 +24,10 @@ type Contribution struct {
 	MetaData  json.RawMessage `json:"metadata"`
 }
 
-var db *sql.DB
+var (
+	db    *sql.DB
+	queue *IngestQueue
+)
 
 func main() {
 	var err error
@@ -64,12 +70,22 @@ func main() {
 		log.Fatalf("Failed to create table: %v", err)
 	}
 
+	// Set up the batched ingestion queue in front of the events table
+	queueMaxLen := envInt("QUEUE_MAX_LEN", 10000)
+	queueBatchSize := envInt("QUEUE_BATCH_SIZE", 500)
+	queue, err = NewIngestQueue("./data/queue.log", queueMaxLen, queueBatchSize, 200*time.Millisecond)
+	if err != nil {
+		log.Fatalf("Failed to start ingest queue: %v", err)
+	}
+
 	// Create a new ServeMux for routing
 	mux := http.NewServeMux()
 
 	// API Routes - using method checks inside handlers for compatibility
 	mux.HandleFunc("/api/contributions", handleContributions)
+	mux.HandleFunc("/api/batches/", handleGetBatch)
 	mux.HandleFunc("/api/stats", handleGetStats)
+	mux.HandleFunc("/api/stats/weekly", handleGetWeeklyStats)
 	mux.HandleFunc("/api/health", handleHealth)
 
 	// Serve static files
@@ -127,7 +143,9 @@ func handleContributions(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// POST: Receive new events
+// POST: Receive new events. This just spills the events to disk and hands
+// them to the ingest queue's writer goroutine, so a burst of concurrent
+// posts doesn't serialize on a SQLite transaction each.
 func handlePostContribution(w http.ResponseWriter, r *http.Request) {
 	var contributions []Contribution
 
@@ -137,49 +155,39 @@ func handlePostContribution(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	tx, err := db.Begin()
-	if err != nil {
-		http.Error(w, "Database error", http.StatusInternalServerError)
+	batchID := queue.NewBatchID(len(contributions))
+	if err := queue.Enqueue(batchID, contributions); err != nil {
+		http.Error(w, "Queue error", http.StatusInternalServerError)
 		return
 	}
 
-	stmt, err := tx.Prepare(`
-		INSERT OR IGNORE INTO events (source, context, timestamp, metadata) 
-		VALUES (?, ?, ?, ?)
-	`)
-	if err != nil {
-		tx.Rollback()
-		http.Error(w, "Database error", http.StatusInternalServerError)
-		return
-	}
-	defer stmt.Close()
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"batch_id": batchID,
+		"queued":   len(contributions),
+		"message":  fmt.Sprintf("Queued %d contributions", len(contributions)),
+	})
 
-	count := 0
-	for _, c := range contributions {
-		metaString := string(c.MetaData)
-		if metaString == "" {
-			metaString = "{}"
-		}
+	fmt.Printf("📥 Queued %d events as batch %s\n", len(contributions), batchID)
+}
 
-		_, err := stmt.Exec(c.Source, c.Context, c.Timestamp, metaString)
-		if err == nil {
-			count++
-		}
+// GET /api/batches/:id - report how many of a batch's events have been
+// committed to SQLite so far.
+func handleGetBatch(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/api/batches/")
+	if id == "" {
+		http.Error(w, "Missing batch id", http.StatusBadRequest)
+		return
 	}
 
-	if err := tx.Commit(); err != nil {
-		http.Error(w, "Database error", http.StatusInternalServerError)
+	status, ok := queue.Status(id)
+	if !ok {
+		http.Error(w, "Unknown batch id", http.StatusNotFound)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"processed": count,
-		"message":   fmt.Sprintf("Processed %d contributions", count),
-	})
-
-	fmt.Printf("📥 Received %d events (from %d submitted)\n", count, len(contributions))
+	writeJSON(w, status)
 }
 
 // GET: Retrieve contributions for the frontend
@@ -329,6 +337,151 @@ func calculateStreak() int {
 	return streak
 }
 
+// WeekData mirrors the shape used by Forgejo's contributors graph: one
+// point per ISO-week (starting Sunday) with commit count and line churn.
+type WeekData struct {
+	Week      int64 `json:"week"`
+	Commits   int   `json:"commits"`
+	Additions int   `json:"additions"`
+	Deletions int   `json:"deletions"`
+}
+
+const weeklyStatsTTL = 10 * time.Minute
+
+type weeklyStatsCacheEntry struct {
+	data      []WeekData
+	expiresAt time.Time
+}
+
+var (
+	weeklyStatsCache   sync.Map // key: "year+source" -> *weeklyStatsCacheEntry
+	weeklyStatsKeyLock sync.Map // key: "year+source" -> *sync.Mutex
+)
+
+// GET: Weekly rollup stats, cached in-memory per year+source with a TTL.
+// Concurrent requests for the same key wait on a single generator instead
+// of each re-scanning the events table.
+func handleGetWeeklyStats(w http.ResponseWriter, r *http.Request) {
+	year := r.URL.Query().Get("year")
+	source := r.URL.Query().Get("source")
+	if year == "" {
+		year = fmt.Sprintf("%d", time.Now().Year())
+	}
+	if _, err := strconv.Atoi(year); err != nil {
+		http.Error(w, "Invalid year parameter", http.StatusBadRequest)
+		return
+	}
+	cacheKey := year + "+" + source
+
+	if entry, ok := weeklyStatsCache.Load(cacheKey); ok {
+		cached := entry.(*weeklyStatsCacheEntry)
+		if time.Now().Before(cached.expiresAt) {
+			writeJSON(w, cached.data)
+			return
+		}
+	}
+
+	lockIface, _ := weeklyStatsKeyLock.LoadOrStore(cacheKey, &sync.Mutex{})
+	lock := lockIface.(*sync.Mutex)
+	lock.Lock()
+	defer lock.Unlock()
+
+	// Another goroutine may have populated the cache while we waited.
+	if entry, ok := weeklyStatsCache.Load(cacheKey); ok {
+		cached := entry.(*weeklyStatsCacheEntry)
+		if time.Now().Before(cached.expiresAt) {
+			writeJSON(w, cached.data)
+			return
+		}
+	}
+
+	data, err := computeWeeklyStats(year, source)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	weeklyStatsCache.Store(cacheKey, &weeklyStatsCacheEntry{
+		data:      data,
+		expiresAt: time.Now().Add(weeklyStatsTTL),
+	})
+	writeJSON(w, data)
+}
+
+// computeWeeklyStats scans the events table for the given year (optionally
+// filtered by source) and buckets commits/additions/deletions by the Sunday
+// starting each ISO-week.
+func computeWeeklyStats(year, source string) ([]WeekData, error) {
+	startDate := year + "-01-01"
+	endDate := fmt.Sprintf("%d-01-01", mustAtoi(year)+1)
+
+	query := `SELECT timestamp, metadata FROM events WHERE timestamp >= ? AND timestamp < ?`
+	args := []interface{}{startDate, endDate}
+	if source != "" {
+		query += " AND source = ?"
+		args = append(args, source)
+	}
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	weeks := make(map[int64]*WeekData)
+	var order []int64
+
+	for rows.Next() {
+		var ts time.Time
+		var metaString string
+		if err := rows.Scan(&ts, &metaString); err != nil {
+			continue
+		}
+
+		weekStart := startOfWeek(ts).Unix()
+		wd, ok := weeks[weekStart]
+		if !ok {
+			wd = &WeekData{Week: weekStart}
+			weeks[weekStart] = wd
+			order = append(order, weekStart)
+		}
+		wd.Commits++
+
+		var meta struct {
+			Stats struct {
+				Additions int `json:"additions"`
+				Deletions int `json:"deletions"`
+			} `json:"stats"`
+		}
+		if json.Unmarshal([]byte(metaString), &meta) == nil {
+			wd.Additions += meta.Stats.Additions
+			wd.Deletions += meta.Stats.Deletions
+		}
+	}
+
+	// The query has no ORDER BY, so rows (and thus order) arrive in
+	// arbitrary SQLite row order; sort chronologically before returning
+	// the series.
+	sort.Slice(order, func(i, j int) bool { return order[i] < order[j] })
+
+	result := make([]WeekData, 0, len(order))
+	for _, week := range order {
+		result = append(result, *weeks[week])
+	}
+	return result, nil
+}
+
+// startOfWeek truncates t to midnight UTC on the preceding (or same) Sunday.
+func startOfWeek(t time.Time) time.Time {
+	t = t.UTC().Truncate(24 * time.Hour)
+	return t.AddDate(0, 0, -int(t.Weekday()))
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
 // Health check endpoint
 func handleHealth(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
@@ -343,3 +496,16 @@ func mustAtoi(s string) int {
 	}
 	return n
 }
+
+// envInt reads an integer env var, falling back to def if unset or invalid.
+func envInt(key string, def int) int {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return def
+	}
+	return n
+}
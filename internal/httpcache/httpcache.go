@@ -0,0 +1,246 @@
+// Package httpcache is a small write-through cache for outbound HTTP GETs,
+// shared by every forge importer so repeated runs don't re-hit rate-limited
+// APIs for data that hasn't changed.
+package httpcache
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// entry is what gets persisted to disk per cache key.
+type entry struct {
+	StoredAt time.Time         `json:"stored_at"`
+	ETag     string            `json:"etag,omitempty"`
+	Status   int               `json:"status"`
+	Headers  map[string]string `json:"headers,omitempty"`
+	Body     []byte            `json:"body"`
+}
+
+// Client performs cached HTTP GETs. A zero TTL disables caching entirely.
+type Client struct {
+	TTL      time.Duration
+	CacheDir string
+	HTTP     *http.Client
+}
+
+// New builds a Client that caches under
+// $XDG_CACHE_HOME/contribution-graph/http (or ~/.cache/... if unset).
+func New(ttl time.Duration) (*Client, error) {
+	base := os.Getenv("XDG_CACHE_HOME")
+	if base == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("httpcache: resolving cache dir: %w", err)
+		}
+		base = filepath.Join(home, ".cache")
+	}
+
+	dir := filepath.Join(base, "contribution-graph", "http")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("httpcache: creating cache dir: %w", err)
+	}
+
+	return &Client{
+		TTL:      ttl,
+		CacheDir: dir,
+		HTTP:     &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// Get performs a GET against u with the given headers, transparently
+// caching the response body (and a few response headers, e.g. pagination
+// links) on disk. If a fresh cache entry exists it is returned without
+// touching the network; if a stale entry has an ETag, the request
+// revalidates with If-None-Match instead of re-downloading.
+func (c *Client) Get(u string, hdr map[string]string) ([]byte, http.Header, error) {
+	if c.TTL <= 0 {
+		return c.fetch(u, hdr)
+	}
+
+	key := cacheKey(u, hdr)
+	path := filepath.Join(c.CacheDir, key)
+
+	if cached, ok := readEntry(path); ok {
+		if time.Since(cached.StoredAt) < c.TTL {
+			return cached.Body, toHeader(cached.Headers), nil
+		}
+		return c.revalidate(u, hdr, path, cached)
+	}
+
+	return c.fetch(u, hdr)
+}
+
+// Refresh always revalidates against the origin — using If-None-Match when
+// a cached ETag exists, and an unconditional GET otherwise — regardless of
+// how fresh the cache entry is. Use it for resources that can still change
+// after being cached (e.g. the current, still-growing period of an
+// otherwise-immutable archive), where Get's TTL would serve a stale copy.
+func (c *Client) Refresh(u string, hdr map[string]string) ([]byte, http.Header, error) {
+	if c.TTL <= 0 {
+		return c.fetch(u, hdr)
+	}
+
+	path := filepath.Join(c.CacheDir, cacheKey(u, hdr))
+	if cached, ok := readEntry(path); ok {
+		return c.revalidate(u, hdr, path, cached)
+	}
+	return c.fetch(u, hdr)
+}
+
+func (c *Client) revalidate(u string, hdr map[string]string, path string, cached entry) ([]byte, http.Header, error) {
+	if cached.ETag == "" {
+		return c.fetch(u, hdr)
+	}
+
+	reqHdr := cloneHeaders(hdr)
+	reqHdr["If-None-Match"] = cached.ETag
+
+	resp, err := c.do(u, reqHdr)
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.status == http.StatusNotModified {
+		cached.StoredAt = time.Now()
+		writeEntry(path, cached)
+		return cached.Body, toHeader(cached.Headers), nil
+	}
+
+	return c.store(path, resp)
+}
+
+func (c *Client) fetch(u string, hdr map[string]string) ([]byte, http.Header, error) {
+	resp, err := c.do(u, hdr)
+	if err != nil {
+		return nil, nil, err
+	}
+	if c.TTL <= 0 {
+		return resp.body, resp.header, nil
+	}
+	return c.store(filepath.Join(c.CacheDir, cacheKey(u, hdr)), resp)
+}
+
+func (c *Client) store(path string, resp *response) ([]byte, http.Header, error) {
+	e := entry{
+		StoredAt: time.Now(),
+		ETag:     resp.etag,
+		Status:   resp.status,
+		Headers:  fromHeader(resp.header),
+		Body:     resp.body,
+	}
+	writeEntry(path, e)
+	return resp.body, resp.header, nil
+}
+
+// cacheableHeaders are the response headers worth persisting alongside the
+// body — enough for callers to page through link-style APIs on a cache hit.
+var cacheableHeaders = []string{"X-Next-Page", "Link"}
+
+type response struct {
+	body   []byte
+	header http.Header
+	status int
+	etag   string
+}
+
+func (c *Client) do(u string, hdr map[string]string) (*response, error) {
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range hdr {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("httpcache: reading response: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("httpcache: %s returned status %d", u, resp.StatusCode)
+	}
+
+	return &response{
+		body:   body,
+		header: resp.Header,
+		status: resp.StatusCode,
+		etag:   resp.Header.Get("ETag"),
+	}, nil
+}
+
+func fromHeader(h http.Header) map[string]string {
+	out := make(map[string]string, len(cacheableHeaders))
+	for _, name := range cacheableHeaders {
+		if v := h.Get(name); v != "" {
+			out[name] = v
+		}
+	}
+	return out
+}
+
+func toHeader(m map[string]string) http.Header {
+	h := make(http.Header, len(m))
+	for k, v := range m {
+		h.Set(k, v)
+	}
+	return h
+}
+
+func cacheKey(u string, hdr map[string]string) string {
+	names := make([]string, 0, len(hdr))
+	for k := range hdr {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	h := sha1.New()
+	io.WriteString(h, u)
+	for _, k := range names {
+		io.WriteString(h, "\n"+k+": "+hdr[k])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func cloneHeaders(hdr map[string]string) map[string]string {
+	out := make(map[string]string, len(hdr)+1)
+	for k, v := range hdr {
+		out[k] = v
+	}
+	return out
+}
+
+func readEntry(path string) (entry, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return entry{}, false
+	}
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return entry{}, false
+	}
+	return e, true
+}
+
+func writeEntry(path string, e entry) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	// Best-effort: a failed cache write shouldn't fail the caller's request.
+	_ = os.WriteFile(path, data, 0644)
+}